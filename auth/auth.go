@@ -0,0 +1,56 @@
+// Package auth は、Gemini（Vertex AI）API呼び出しに使うGoogle Cloudの
+// アクセストークンを、gcloud CLIのサブプロセス起動なしで取得します。
+//
+// Application Default Credentials（ADC）の探索順に従うため、
+// サービスアカウントJSONキー（-credentials または GOOGLE_APPLICATION_CREDENTIALS）、
+// gcloud CLIのユーザー認証情報、GKE/Cloud Run上のWorkload Identity（メタデータサーバー）の
+// いずれでも、コードの変更なしに動作します。
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// Scope は、Vertex AI（Gemini）APIを呼び出すために必要なOAuthスコープです。
+const Scope = "https://www.googleapis.com/auth/cloud-platform"
+
+// NewTokenSource は、ADCに基づいてトークンを自動更新する oauth2.TokenSource を返します。
+// credentialsPath が空でない場合は、そのサービスアカウントJSONキーを優先して使います。
+// 空の場合は GOOGLE_APPLICATION_CREDENTIALS 環境変数、gcloudのユーザー認証情報、
+// GKE/Cloud Run上のWorkload Identity/メタデータサーバーの順にADCが解決します。
+func NewTokenSource(ctx context.Context, credentialsPath string) (oauth2.TokenSource, error) {
+	if credentialsPath == "" {
+		credentialsPath = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+
+	var creds *google.Credentials
+	var err error
+	if credentialsPath != "" {
+		data, readErr := os.ReadFile(credentialsPath)
+		if readErr != nil {
+			return nil, fmt.Errorf("auth: 認証情報ファイル読み込み失敗: %w", readErr)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, Scope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, Scope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: ADCの解決に失敗しました: %w", err)
+	}
+
+	// oauth2.ReuseTokenSource でラップし、有効なトークンはキャッシュして
+	// 期限が近づいたら自動的に更新する（トークン取得をリクエストごとに行わない）。
+	return oauth2.ReuseTokenSource(nil, creds.TokenSource), nil
+}
+
+// NewHTTPClient は、ts を使ってAuthorizationヘッダーを自動付与する *http.Client を返します。
+// Gemini APIへのリクエストは、これを使い回すことでトークン取得のオーバーヘッドを避けられます。
+func NewHTTPClient(ctx context.Context, ts oauth2.TokenSource) *http.Client {
+	return oauth2.NewClient(ctx, ts)
+}
@@ -0,0 +1,60 @@
+// Package cache は、すでに selectedTheme として使ったトレンドタイトルを
+// 一定期間（TTL）覚えておくための、小さなKVキャッシュです。
+//
+// cron駆動で NetaBako を繰り返し実行したときに、同じバズワードに何度も
+// 着地してしまうのを防ぐために、main はランダム選択の前に Store.Seen で
+// 既出のタイトルを除外し、選択後に Store.Mark で記録します。
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Store は、正規化済みタイトルをキーにした既出チェック用のキャッシュです。
+// ファイルベース（デフォルト）とRedisの2実装を想定していますが、
+// 新しいバックエンドを追加する場合もこのインターフェースだけ満たせば足ります。
+type Store interface {
+	// Seen は、key がまだTTL内に記録されているかどうかを返します。
+	Seen(ctx context.Context, key string) (bool, error)
+	// Mark は、key を現在時刻起点のTTLで記録します。
+	Mark(ctx context.Context, key string) error
+	// Forget は、key の記録を削除します（--forget サブコマンド用）。
+	// key が空文字列の場合は、保持している全件を削除します。
+	Forget(ctx context.Context, key string) error
+	// Close は、内部で保持しているリソース（ファイルハンドル、接続など）を解放します。
+	Close() error
+}
+
+// Normalize は、タイトルをキャッシュのキーとして使うために正規化します。
+// 小文字化・Unicode NFKC正規化・前後の空白除去を行うことで、
+// 「大谷翔平 ホームラン」と「大谷翔平　ホームラン」のような表記揺れを吸収します。
+func Normalize(title string) string {
+	s := norm.NFKC.String(title)
+	s = strings.ToLower(s)
+	s = strings.TrimSpace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	return s
+}
+
+// Open は、DSN文字列からバックエンドを判別して Store を開きます。
+// 対応する形式:
+//
+//	file:/path/to/dedupe.json (スキームなし・"file:"省略時はファイルパスとして扱う)
+//	redis://host:port/db
+func Open(dsn string, ttl time.Duration) (Store, error) {
+	switch {
+	case strings.HasPrefix(dsn, "redis://"):
+		return newRedisStore(dsn, ttl)
+	case strings.HasPrefix(dsn, "file:"):
+		return newFileStore(strings.TrimPrefix(dsn, "file:"), ttl)
+	case dsn == "":
+		return nil, fmt.Errorf("cache: dedupe-store が空です")
+	default:
+		return newFileStore(dsn, ttl)
+	}
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// fileStore は、正規化済みキー→有効期限のマップをJSONファイルに永続化する
+// Store 実装です。プロセス内の同時アクセスは mu で、複数プロセス
+// （複数のcronワーカーや、cron実行と重なった手動実行）からの書き込みは
+// path+".lock" に対するflock(2)のファイルロックで直列化し、ロックを
+// 握った状態でファイルを読み直してから（read-modify-write）上書きします。
+type fileStore struct {
+	path string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]time.Time // key -> 記録時刻（Seen判定時に ttl を足して比較する）
+}
+
+func newFileStore(path string, ttl time.Duration) (*fileStore, error) {
+	s := &fileStore{path: path, ttl: ttl}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileStore) load() error {
+	s.entries = map[string]time.Time{}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	var raw map[string]time.Time
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.entries = raw
+	return nil
+}
+
+// saveLocked は、呼び出し側が s.mu を保持している前提でファイルに書き戻します。
+func (s *fileStore) saveLocked() error {
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// withFileLock は、path+".lock" に対するflock(2)の排他ロックを取得した状態で、
+// 他プロセスが書いた最新の内容を読み直してから mutate を呼び、
+// mutate が変更ありと報告した場合のみファイルに書き戻します。
+// これにより、複数プロセスが同時に Mark/Forget しても互いの書き込みを
+// 上書きしてしまうことがなくなります（呼び出し側が s.mu を保持している前提）。
+func (s *fileStore) withFileLock(mutate func() (changed bool, err error)) error {
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	lockFile, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	defer lockFile.Close()
+
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	changed, err := mutate()
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveLocked()
+}
+
+func (s *fileStore) Seen(ctx context.Context, key string) (bool, error) {
+	key = Normalize(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var seen bool
+	err := s.withFileLock(func() (bool, error) {
+		recordedAt, ok := s.entries[key]
+		if !ok {
+			return false, nil
+		}
+		if time.Since(recordedAt) > s.ttl {
+			// 期限切れなので掃除しておく
+			delete(s.entries, key)
+			return true, nil
+		}
+		seen = true
+		return false, nil
+	})
+	return seen, err
+}
+
+func (s *fileStore) Mark(ctx context.Context, key string) error {
+	key = Normalize(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.withFileLock(func() (bool, error) {
+		s.entries[key] = time.Now()
+		return true, nil
+	})
+}
+
+func (s *fileStore) Forget(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.withFileLock(func() (bool, error) {
+		if key == "" {
+			s.entries = map[string]time.Time{}
+			return true, nil
+		}
+		delete(s.entries, Normalize(key))
+		return true, nil
+	})
+}
+
+func (s *fileStore) Close() error {
+	return nil
+}
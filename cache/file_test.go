@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct{ a, b string }{
+		{"大谷翔平 ホームラン", "大谷翔平　ホームラン"}, // 全角スペース(NFKC/Fieldsで吸収)
+		{"  Hello World  ", "hello world"},
+		{"ＡＢＣ", "abc"}, // 全角英字(NFKC)
+	}
+	for _, c := range cases {
+		if got, want := Normalize(c.a), Normalize(c.b); got != want {
+			t.Errorf("Normalize(%q)=%q, Normalize(%q)=%q, want equal", c.a, got, c.b, want)
+		}
+	}
+}
+
+func TestFileStoreSeenAndMark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.json")
+	store, err := newFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	ctx := context.Background()
+
+	seen, err := store.Seen(ctx, "大谷翔平 ホームラン")
+	if err != nil {
+		t.Fatalf("Seen: %v", err)
+	}
+	if seen {
+		t.Fatalf("未記録のキーがSeen=trueになった")
+	}
+
+	if err := store.Mark(ctx, "大谷翔平 ホームラン"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+
+	seen, err = store.Seen(ctx, "大谷翔平　ホームラン") // 全角スペース違いでも同一視される
+	if err != nil {
+		t.Fatalf("Seen: %v", err)
+	}
+	if !seen {
+		t.Fatalf("記録済みキーがSeen=falseになった")
+	}
+
+	// 再読み込みしても永続化されていること
+	reopened, err := newFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("newFileStore (reopen): %v", err)
+	}
+	seen, err = reopened.Seen(ctx, "大谷翔平 ホームラン")
+	if err != nil {
+		t.Fatalf("Seen (reopen): %v", err)
+	}
+	if !seen {
+		t.Fatalf("再読み込み後に記録が失われた")
+	}
+}
+
+func TestFileStoreExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.json")
+	store, err := newFileStore(path, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Mark(ctx, "一時的な話題"); err != nil {
+		t.Fatalf("Mark: %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	seen, err := store.Seen(ctx, "一時的な話題")
+	if err != nil {
+		t.Fatalf("Seen: %v", err)
+	}
+	if seen {
+		t.Fatalf("TTLを過ぎたキーがSeen=trueのままになっている")
+	}
+}
+
+func TestFileStoreForget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.json")
+	store, err := newFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	ctx := context.Background()
+
+	store.Mark(ctx, "A")
+	store.Mark(ctx, "B")
+
+	if err := store.Forget(ctx, "A"); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	if seen, _ := store.Seen(ctx, "A"); seen {
+		t.Fatalf("Forgetしたキーが残っている")
+	}
+	if seen, _ := store.Seen(ctx, "B"); !seen {
+		t.Fatalf("Forgetしていないキーまで消えた")
+	}
+
+	if err := store.Forget(ctx, ""); err != nil {
+		t.Fatalf("Forget (全件): %v", err)
+	}
+	if seen, _ := store.Seen(ctx, "B"); seen {
+		t.Fatalf("全件Forget後もキーが残っている")
+	}
+}
+
+func TestFileStoreConcurrentAccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.json")
+	store, err := newFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("newFileStore: %v", err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := "topic"
+			if i%2 == 0 {
+				store.Mark(ctx, key)
+			} else {
+				store.Seen(ctx, key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen, err := store.Seen(ctx, "topic")
+	if err != nil {
+		t.Fatalf("Seen: %v", err)
+	}
+	if !seen {
+		t.Fatalf("並行Markのあとにキーが記録されていない")
+	}
+}
+
+// TestFileStoreCrossProcessMarkDoesNotClobber は、同じファイルを指す
+// 複数の fileStore インスタンス（≒複数プロセス）が同時に Mark しても、
+// 互いの書き込みを上書きしてしまわないことを確認します。
+func TestFileStoreCrossProcessMarkDoesNotClobber(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedupe.json")
+	ctx := context.Background()
+
+	const n = 20
+	stores := make([]*fileStore, n)
+	for i := 0; i < n; i++ {
+		store, err := newFileStore(path, time.Hour)
+		if err != nil {
+			t.Fatalf("newFileStore(%d): %v", i, err)
+		}
+		stores[i] = store
+	}
+
+	var wg sync.WaitGroup
+	for i, store := range stores {
+		wg.Add(1)
+		go func(i int, store *fileStore) {
+			defer wg.Done()
+			if err := store.Mark(ctx, fmt.Sprintf("topic-%d", i)); err != nil {
+				t.Errorf("Mark(%d): %v", i, err)
+			}
+		}(i, store)
+	}
+	wg.Wait()
+
+	final, err := newFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatalf("newFileStore (final): %v", err)
+	}
+	for i := 0; i < n; i++ {
+		seen, err := final.Seen(ctx, fmt.Sprintf("topic-%d", i))
+		if err != nil {
+			t.Fatalf("Seen(%d): %v", i, err)
+		}
+		if !seen {
+			t.Errorf("topic-%d が別インスタンスのMarkで上書きされて消えた", i)
+		}
+	}
+}
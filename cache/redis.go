@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStore は、fileStore と同じ Store インターフェースをRedis上で実装したものです。
+// 複数プロセス/複数ホストから同じキャッシュを共有したい運用（例: 複数のcronワーカー）向け。
+type redisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+	prefix string
+}
+
+func newRedisStore(dsn string, ttl time.Duration) (*redisStore, error) {
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cache: redis dsn 解析失敗: %w", err)
+	}
+	return &redisStore{
+		client: redis.NewClient(opts),
+		ttl:    ttl,
+		prefix: "netabako:dedupe:",
+	}, nil
+}
+
+func (s *redisStore) Seen(ctx context.Context, key string) (bool, error) {
+	n, err := s.client.Exists(ctx, s.prefix+Normalize(key)).Result()
+	if err != nil {
+		return false, fmt.Errorf("cache: redis EXISTS: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (s *redisStore) Mark(ctx context.Context, key string) error {
+	if err := s.client.Set(ctx, s.prefix+Normalize(key), time.Now().Format(time.RFC3339), s.ttl).Err(); err != nil {
+		return fmt.Errorf("cache: redis SET: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) Forget(ctx context.Context, key string) error {
+	if key == "" {
+		iter := s.client.Scan(ctx, 0, s.prefix+"*", 0).Iterator()
+		for iter.Next(ctx) {
+			if err := s.client.Del(ctx, iter.Val()).Err(); err != nil {
+				return fmt.Errorf("cache: redis DEL: %w", err)
+			}
+		}
+		return iter.Err()
+	}
+	if err := s.client.Del(ctx, s.prefix+Normalize(key)).Err(); err != nil {
+		return fmt.Errorf("cache: redis DEL: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
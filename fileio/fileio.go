@@ -0,0 +1,10 @@
+// Package fileio は、設定ファイル読み込み(YAMLなど)で共通して使う
+// 薄いファイル読み取りラッパーを提供します。
+package fileio
+
+import "os"
+
+// FileIoRead は、path の内容をそのままバイト列で返します。
+func FileIoRead(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
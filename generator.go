@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Result は、Generate の1回分の生成結果です。
+// Source と Rank は Gemini呼び出し自体には関与せず、話題をトレンドから選んだ場合に
+// 呼び出し元（main）が後から設定する出典情報です。テーマを手動指定した場合は空のままです。
+type Result struct {
+	PromptKey  string   `json:"prompt_key"`
+	Theme      string   `json:"theme"`
+	Source     string   `json:"source,omitempty"`
+	Rank       int      `json:"rank,omitempty"`
+	Candidates []string `json:"candidates"`
+}
+
+// Chunk は、GenerateStream が順次送出するGemini応答の断片です。
+// Err が空でない場合、ストリーム途中でのエラー発生を表し、以降のChunkは送出されません。
+type Chunk struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+	Err  string `json:"error,omitempty"`
+}
+
+// Generator は、プロンプトテンプレートとGemini APIへの呼び出しをまとめ、
+// CLI（main）とHTTPハンドラの両方から共有される生成ロジックです。
+// HTTPClient は、認証ヘッダーの付与込みで呼び出し元（main）が組み立てて渡します
+// （auth.NewHTTPClient 参照）。リクエストごとのトークン取得は発生しません。
+type Generator struct {
+	Prompts    map[string]string
+	ProjectID  string
+	Location   string
+	ModelID    string
+	APIBaseURL string
+	HTTPClient *http.Client
+}
+
+// NewGenerator は、プロンプトテンプレートと認証済みHTTPクライアントからGeneratorを組み立てます。
+func NewGenerator(prompts map[string]string, projectID, location, modelID string, httpClient *http.Client) *Generator {
+	return &Generator{
+		Prompts:    prompts,
+		ProjectID:  projectID,
+		Location:   location,
+		ModelID:    modelID,
+		APIBaseURL: apiBaseURL,
+		HTTPClient: httpClient,
+	}
+}
+
+func (g *Generator) buildUserInput(key, theme string) (string, error) {
+	template, ok := g.Prompts[key]
+	if !ok {
+		return "", fmt.Errorf("プロンプトキー %q が見つかりません", key)
+	}
+	return strings.ReplaceAll(template, "{{THEME}}", theme), nil
+}
+
+func (g *Generator) newRequest(ctx context.Context, endpoint, userInput string) (*http.Request, error) {
+	requestBody := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"role": "user",
+				"parts": []map[string]string{
+					{"text": userInput},
+				},
+			},
+		},
+	}
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	// Authorizationヘッダーは g.HTTPClient の oauth2.Transport が自動付与する。
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// Generate は、従来の generateContent（非ストリーミング）エンドポイントを1回呼び出し、
+// 候補テキストをまとめて返します。
+func (g *Generator) Generate(ctx context.Context, key, theme string) (Result, error) {
+	userInput, err := g.buildUserInput(key, theme)
+	if err != nil {
+		return Result{}, err
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s/projects/%s/locations/%s/publishers/google/models/%s:generateContent",
+		g.APIBaseURL, g.ProjectID, g.Location, g.ModelID,
+	)
+	req, err := g.newRequest(ctx, endpoint, userInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("gemini request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var parsed GeminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Result{}, fmt.Errorf("gemini response decode: %w", err)
+	}
+
+	candidates := make([]string, 0, len(parsed.Candidates))
+	for _, c := range parsed.Candidates {
+		for _, p := range c.Content.Parts {
+			candidates = append(candidates, p.Text)
+		}
+	}
+	return Result{PromptKey: key, Theme: theme, Candidates: candidates}, nil
+}
+
+// GenerateStream は、Vertex AIの streamGenerateContent エンドポイントを呼び出し、
+// 返ってきたテキスト片を随時 Chunk として流します。呼び出し元（HTTPハンドラ）が
+// ctx をキャンセルすれば、途中でも読み取りが打ち切られます。
+func (g *Generator) GenerateStream(ctx context.Context, key, theme string) (<-chan Chunk, error) {
+	userInput, err := g.buildUserInput(key, theme)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s/projects/%s/locations/%s/publishers/google/models/%s:streamGenerateContent",
+		g.APIBaseURL, g.ProjectID, g.Location, g.ModelID,
+	)
+	req, err := g.newRequest(ctx, endpoint, userInput)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gemini stream request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("gemini stream request failed (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	chunks := make(chan Chunk)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		// streamGenerateContentは GeminiResponse の配列を返すので、
+		// 先頭の '[' を読み飛ばしてから1要素ずつデコードする。
+		if _, err := dec.Token(); err != nil {
+			sendChunkErr(ctx, chunks, err)
+			return
+		}
+		for dec.More() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			var part GeminiResponse
+			if err := dec.Decode(&part); err != nil {
+				sendChunkErr(ctx, chunks, err)
+				return
+			}
+			for _, c := range part.Candidates {
+				for _, p := range c.Content.Parts {
+					select {
+					case chunks <- Chunk{Text: p.Text}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// sendChunkErr は、ストリーム読み取り中に発生したエラーを、呼び出し元が
+// SSEイベントとしてそのままクライアントに伝えられるよう Chunk に詰めて送出します。
+func sendChunkErr(ctx context.Context, chunks chan<- Chunk, err error) {
+	select {
+	case chunks <- Chunk{Err: err.Error()}:
+	case <-ctx.Done():
+	}
+}
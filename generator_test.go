@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestGenerator(baseURL string) *Generator {
+	return &Generator{
+		Prompts:    map[string]string{"default": "{{THEME}}"},
+		ProjectID:  "test-project",
+		Location:   "us-central1",
+		ModelID:    "gemini-test",
+		APIBaseURL: baseURL,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func TestGenerateStreamPropagatesUpstreamErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":401,"message":"認証に失敗しました"}}`))
+	}))
+	defer srv.Close()
+
+	g := newTestGenerator(srv.URL)
+	chunks, err := g.GenerateStream(context.Background(), "default", "テーマ")
+	if err == nil {
+		t.Fatal("上流の401エラーがerrとして返りませんでした")
+	}
+	if chunks != nil {
+		t.Fatal("エラー時にchunksチャンネルが返されました")
+	}
+	if !strings.Contains(err.Error(), "401") {
+		t.Errorf("エラーメッセージにステータスコードが含まれていません: %v", err)
+	}
+}
+
+func TestGenerateStreamSendsErrorChunkOnMalformedBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`not a json array`))
+	}))
+	defer srv.Close()
+
+	g := newTestGenerator(srv.URL)
+	chunks, err := g.GenerateStream(context.Background(), "default", "テーマ")
+	if err != nil {
+		t.Fatalf("GenerateStream: %v", err)
+	}
+
+	chunk, ok := <-chunks
+	if !ok {
+		t.Fatal("不正なボディに対してエラーChunkが送出されませんでした")
+	}
+	if chunk.Err == "" {
+		t.Fatalf("Chunk.Errが空でした: %+v", chunk)
+	}
+	if _, ok := <-chunks; ok {
+		t.Fatal("エラーChunkの後にさらにChunkが送出されました")
+	}
+}
@@ -1,28 +1,29 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"math/rand"
-	"net/http"
-	"os/exec"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
+	"Netabako/auth"
+	"Netabako/cache"
 	"Netabako/fileio"
+	"Netabako/sinks"
+	"Netabako/trends"
 
 	"gopkg.in/yaml.v2"
 )
 
 const (
-	projectID  = "neta-bako"                            // ← あなたのプロジェクトID
-	location   = "us-central1"                          // ← あなたのロケーション（例: us-central1）
-	modelID    = "gemini-2.5-pro"                       // ← 使用するモデルID（例: gemini-1.0-pro）
+	projectID  = "neta-bako"                            // ← デフォルトのプロジェクトID（-project / NETABAKO_PROJECT_ID で上書き可）
+	location   = "us-central1"                          // ← デフォルトのロケーション（-location / NETABAKO_LOCATION で上書き可）
+	modelID    = "gemini-2.5-pro"                       // ← デフォルトのモデルID（-model / NETABAKO_MODEL_ID で上書き可）
 	apiBaseURL = "https://aiplatform.googleapis.com/v1" // Gemini APIのベースURL
 	// 上記のURLは、実際のAPIエンドポイントに合わせて調整してください)
 )
@@ -37,15 +38,14 @@ type GeminiResponse struct {
 	} `json:"candidates"`
 }
 
-// getAccessToken は、gcloud CLIを使ってGoogle Cloudのアクセストークンを取得します。
-// このトークンは、Gemini APIへの認証に使用されます。
-func getAccessToken() (string, error) {
-	cmd := exec.Command("gcloud", "auth", "application-default", "print-access-token")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("トークン取得失敗: %v", err)
+// envOrDefault は、環境変数 key が設定されていればその値を、なければ def を返します。
+// project/location/modelID のように、CLIフラグ・環境変数・デフォルト値の優先順位を
+// 揃えたい設定値に使います。
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-	return strings.TrimSpace(string(output)), nil
+	return def
 }
 
 // LoadPromptsYaml は、指定されたパスからYAMLファイルを読み込み、マップ形式で返します。
@@ -61,15 +61,228 @@ func LoadPromptsYaml(path string) (map[string]string, error) {
 	return result, nil
 }
 
+// sourceYAML は、sources.yaml の1ソース分の設定をそのままパースするための型です。
+type sourceYAML struct {
+	Weight        float64 `yaml:"weight"`
+	Geo           string  `yaml:"geo"`
+	URL           string  `yaml:"url"`
+	Keyword       string  `yaml:"keyword"`
+	Service       string  `yaml:"service"`
+	Area          string  `yaml:"area"`
+	Genre         string  `yaml:"genre"`
+	Date          string  `yaml:"date"`
+	ItemSelector  string  `yaml:"item_selector"`
+	TitleSelector string  `yaml:"title_selector"`
+	LinkSelector  string  `yaml:"link_selector"`
+}
+
+// LoadSourcesYaml は、sources.yaml を読み込み、trends.TrendSource を構築するための
+// Config をソース名ごとに返します。ファイルが存在しない場合は空のマップを返し、
+// 各ソースはデフォルト値で動作します。
+func LoadSourcesYaml(path string) (map[string]trends.Config, error) {
+	data, err := fileio.FileIoRead(path)
+	if err != nil {
+		return map[string]trends.Config{}, nil
+	}
+	var raw map[string]sourceYAML
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	configs := make(map[string]trends.Config, len(raw))
+	for name, y := range raw {
+		configs[name] = trends.Config{
+			Weight:        y.Weight,
+			Geo:           y.Geo,
+			URL:           y.URL,
+			Keyword:       y.Keyword,
+			Service:       y.Service,
+			Area:          y.Area,
+			Genre:         y.Genre,
+			Date:          y.Date,
+			ItemSelector:  y.ItemSelector,
+			TitleSelector: y.TitleSelector,
+			LinkSelector:  y.LinkSelector,
+		}
+	}
+	return configs, nil
+}
+
+// envVarPattern は、YAML設定内の ${ENV_VAR} 形式のプレースホルダにマッチします。
+// Webhook URLやトークンを設定ファイルに平文で書かずに済むようにするためのものです。
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnvVars は、s 中の ${ENV_VAR} を対応する環境変数の値に置き換えます。
+// 該当する環境変数が設定されていない場合は空文字列に置き換えます。
+func expandEnvVars(s string) string {
+	return envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := envVarPattern.FindStringSubmatch(match)[1]
+		return os.Getenv(name)
+	})
+}
+
+// sinkYAML は、sinks.yaml の1シンク分の設定をそのままパースするための型です。
+// webhook_url・channel_access_token・to_user_id の値には ${ENV_VAR} 形式の
+// プレースホルダを使え、読み込み時に環境変数で展開されます（それ以外の値は展開されません）。
+type sinkYAML struct {
+	WebhookURL         string `yaml:"webhook_url"`
+	Channel            string `yaml:"channel"`
+	IconEmoji          string `yaml:"icon_emoji"`
+	Username           string `yaml:"username"`
+	ChannelAccessToken string `yaml:"channel_access_token"`
+	ToUserID           string `yaml:"to_user_id"`
+	Path               string `yaml:"path"`
+}
+
+// LoadSinksYaml は、sinks.yaml を読み込み、sinks.New に渡す Config をシンク名ごとに
+// 返します。ファイルが存在しない場合は空のマップを返し、その場合は -sink で指定した
+// シンクのうち、設定なしで構築できる file シンクだけが動作します。
+func LoadSinksYaml(path string) (map[string]sinks.Config, error) {
+	data, err := fileio.FileIoRead(path)
+	if err != nil {
+		return map[string]sinks.Config{}, nil
+	}
+	var raw map[string]sinkYAML
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	configs := make(map[string]sinks.Config, len(raw))
+	for name, y := range raw {
+		configs[name] = sinks.Config{
+			WebhookURL:         expandEnvVars(y.WebhookURL),
+			Channel:            y.Channel,
+			IconEmoji:          y.IconEmoji,
+			Username:           y.Username,
+			ChannelAccessToken: expandEnvVars(y.ChannelAccessToken),
+			ToUserID:           expandEnvVars(y.ToUserID),
+			Path:               y.Path,
+		}
+	}
+	return configs, nil
+}
+
+// publishToSinks は、指定されたシンク名ごとに生成・配信を行います。1つのシンクが
+// 失敗しても処理は止めず、警告を出して残りのシンクで続行します。
+func publishToSinks(ctx context.Context, names []string, configs map[string]sinks.Config, payload sinks.Payload) {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		sink, err := sinks.New(name, configs[name])
+		if err != nil {
+			log.Printf("WARN: sink %s: %v", name, err)
+			continue
+		}
+		if err := sink.Publish(ctx, payload); err != nil {
+			log.Printf("WARN: %s publish: %v", name, err)
+			continue
+		}
+	}
+}
+
+// fetchTrends は、名前で指定された各ソースを構築して1つずつ順に取得し、結果をまとめて返します。
+// 1つのソースが失敗しても処理は止めず、警告を出して残りのソースで続行します。
+func fetchTrends(ctx context.Context, names []string, configs map[string]trends.Config, max int) []trends.SourceResult {
+	results := make([]trends.SourceResult, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		src, err := trends.New(name, configs[name])
+		if err != nil {
+			log.Printf("WARN: source %s: %v", name, err)
+			continue
+		}
+		topics, err := src.Fetch(ctx, max)
+		if err != nil {
+			log.Printf("WARN: %s fetch: %v", name, err)
+			continue
+		}
+		results = append(results, trends.SourceResult{Name: src.Name(), Weight: src.Weight(), Topics: topics})
+	}
+	return results
+}
+
+// printScoreDebug は、-debug-score 指定時に各トピックのスコア内訳を標準出力に表示します。
+func printScoreDebug(topics []trends.Topic) {
+	fmt.Println("\n=== Score Debug ===")
+	for i, t := range topics {
+		fmt.Printf("%2d. %-30s Score=%.3f (base=%.3f, traffic=%.3f, agreement=%.3f)\n",
+			i+1, t.Title, t.Score, t.ScoreBase, t.ScoreTraffic, t.ScoreAgreement)
+	}
+}
+
+// filterSeen は、dedupeキャッシュにTTL内で記録済みのトピックを除外します。
+func filterSeen(ctx context.Context, store cache.Store, topics []trends.Topic) []trends.Topic {
+	out := make([]trends.Topic, 0, len(topics))
+	for _, t := range topics {
+		seen, err := store.Seen(ctx, t.Title)
+		if err != nil {
+			log.Printf("WARN: dedupeキャッシュの参照失敗: %v", err)
+			out = append(out, t)
+			continue
+		}
+		if !seen {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// runForget は、`netabako --forget` サブコマンドを処理します。
+// 指定したタイトル（既出チェック用に正規化される）、または -title 未指定なら
+// 全件を、重複排除キャッシュから削除します。
+func runForget(args []string) {
+	fs := flag.NewFlagSet("forget", flag.ExitOnError)
+	dedupeStore := fs.String("dedupe-store", "file:./dedupe.json", "重複排除キャッシュの接続先（例: file:./dedupe.json, redis://localhost:6379/0）")
+	title := fs.String("title", "", "削除するタイトル（未指定の場合は全件削除）")
+	fs.Parse(args)
+
+	store, err := cache.Open(*dedupeStore, 24*time.Hour)
+	if err != nil {
+		fmt.Println("⚠️ 重複排除キャッシュを開けませんでした:", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	if err := store.Forget(context.Background(), *title); err != nil {
+		fmt.Println("⚠️ キャッシュの削除に失敗しました:", err)
+		os.Exit(1)
+	}
+	if *title == "" {
+		fmt.Println("🗑️ 重複排除キャッシュを全件削除しました")
+	} else {
+		fmt.Printf("🗑️ 重複排除キャッシュから %q を削除しました\n", *title)
+	}
+}
+
 // このコードは、Google CloudのGemini APIを使ってSNS投稿のネタを生成するサンプルです。
-// 実行には、gcloud CLIがインストールされており、認証済みである必要があります。
+// 認証はApplication Default Credentials（サービスアカウントキー、gcloudのユーザー認証情報、
+// またはGKE/Cloud Run上のWorkload Identity）で行われ、gcloud CLIは必須ではありません。
 func main() {
+	// --forget は他のフラグと衝突しないよう、flag.Parse() より前にサブコマンドとして処理する
+	if len(os.Args) > 1 && os.Args[1] == "--forget" {
+		runForget(os.Args[2:])
+		return
+	}
+
 	// プロンプトの読み込み
 	isSearchTopic := flag.Bool("searchtopic", false, "リアルタイムトピック検索を有効にします")
 	promptKey := flag.String("prompt", "", "使用するプロンプトのキーを指定します（例: X)")
 	promptKeyShort := flag.String("p", "", "使用するプロンプトのキーを短縮形で指定します（例: X)")
 	themeKey := flag.String("theme", "", "テーマを指定します（例: 旅行）")
 	themeKeyShort := flag.String("t", "", "テーマを短縮形で指定します（例: 旅行）")
+	sourcesFlag := flag.String("sources", "yahoo,google", "トレンド取得に使うソース名をカンマ区切りで指定します（例: yahoo,google,nhk,gnews,note）")
+	dedupeTTL := flag.Duration("dedupe-ttl", 24*time.Hour, "一度選んだトピックを再選択しないようにする期間")
+	dedupeStore := flag.String("dedupe-store", "file:./dedupe.json", "重複排除キャッシュの接続先（例: file:./dedupe.json, redis://localhost:6379/0）")
+	debugScore := flag.Bool("debug-score", false, "マージ後の各トピックのスコア内訳を表示します")
+	sinkFlag := flag.String("sink", "", "生成結果を配信するシンク名をカンマ区切りで指定します（例: slack,file）。未指定なら配信しません")
+	serveAddr := flag.String("serve", "", "指定すると、CLIではなくHTTPサーバとして起動します（例: :8080）")
+	projectFlag := flag.String("project", envOrDefault("NETABAKO_PROJECT_ID", projectID), "Google CloudのプロジェクトID")
+	locationFlag := flag.String("location", envOrDefault("NETABAKO_LOCATION", location), "Vertex AIのロケーション（例: us-central1）")
+	modelFlag := flag.String("model", envOrDefault("NETABAKO_MODEL_ID", modelID), "使用するGeminiのモデルID")
+	credentialsFlag := flag.String("credentials", "", "サービスアカウントJSONキーのパス（未指定ならADCを使用。GOOGLE_APPLICATION_CREDENTIALSでも指定可）")
 	flag.Parse()
 
 	// YAMLファイルからプロンプトを読み込む
@@ -80,6 +293,41 @@ func main() {
 	}
 	//fmt.Println("🔍 プロンプトテンプレート:", prompts)
 
+	authCtx := context.Background()
+	tokenSource, err := auth.NewTokenSource(authCtx, *credentialsFlag)
+	if err != nil {
+		fmt.Println("⚠️ Google Cloud認証情報の解決に失敗しました:", err)
+		return
+	}
+	httpClient := auth.NewHTTPClient(authCtx, tokenSource)
+
+	generator := NewGenerator(prompts, *projectFlag, *locationFlag, *modelFlag, httpClient)
+
+	if *serveAddr != "" {
+		sourceConfigs, err := LoadSourcesYaml("./sources.yaml")
+		if err != nil {
+			fmt.Println("⚠️ ソース設定の読み込み失敗:", err)
+			return
+		}
+		dedupe, err := cache.Open(*dedupeStore, *dedupeTTL)
+		if err != nil {
+			fmt.Println("⚠️ 重複排除キャッシュを開けませんでした:", err)
+			return
+		}
+		defer dedupe.Close()
+
+		cfg := serveConfig{
+			generator:     generator,
+			sourceNames:   strings.Split(*sourcesFlag, ","),
+			sourceConfigs: sourceConfigs,
+			dedupe:        dedupe,
+		}
+		if err := runServe(*serveAddr, cfg); err != nil {
+			log.Fatalf("サーバ起動失敗: %v", err)
+		}
+		return
+	}
+
 	// プロンプトのキーを決定
 	selectedKey := *promptKey
 	if *promptKeyShort != "" {
@@ -90,32 +338,46 @@ func main() {
 		return
 	}
 	// プロンプトキーの存在チェック
-	template, ok := prompts[selectedKey]
-	if !ok {
+	if _, ok := prompts[selectedKey]; !ok {
 		fmt.Printf("⚠️ プロンプトキー '%s' が見つかりません。利用可能なキー: %v\n", selectedKey, prompts)
 		return
 	}
 	var selectedTheme string
+	var selectedSource string
+	var selectedRank int
 
 	// リアルタイムトピック検索
 	if *isSearchTopic {
 
 		ctx := context.Background()
 
-		yahoo, err := fetchYahooRealtime(ctx, 10)
+		sourceConfigs, err := LoadSourcesYaml("./sources.yaml")
 		if err != nil {
-			log.Printf("WARN: yahoo fetch: %v", err)
+			fmt.Println("⚠️ ソース設定の読み込み失敗:", err)
+			return
 		}
-		google, err := fetchGoogleTrends(ctx, "JP", 10)
-		if err != nil {
-			log.Printf("WARN: google fetch: %v", err)
+
+		results := fetchTrends(ctx, strings.Split(*sourcesFlag, ","), sourceConfigs, 10)
+		if len(results) == 0 {
+			log.Fatal("どのソースからもトピックを取得できませんでした。-sources の指定とネットワーク/セレクタを確認してください。")
+		}
+
+		merged := trends.MergeAndRank(results, 10)
+		if *debugScore {
+			printScoreDebug(merged)
 		}
 
-		if len(yahoo) == 0 && len(google) == 0 {
-			log.Fatal("どちらからもトピックを取得できませんでした。ネットワーク/セレクタを確認してください。")
+		dedupe, err := cache.Open(*dedupeStore, *dedupeTTL)
+		if err != nil {
+			fmt.Println("⚠️ 重複排除キャッシュを開けませんでした:", err)
+			return
 		}
+		defer dedupe.Close()
 
-		merged := mergeAndRank(yahoo, google, 10)
+		merged = filterSeen(ctx, dedupe, merged)
+		if len(merged) == 0 {
+			log.Fatal("未使用のトピックがありませんでした（dedupe-ttl内で全て使用済み）。")
+		}
 
 		/*
 			fmt.Println("=== Yahoo Realtime ===")
@@ -144,6 +406,12 @@ func main() {
 		rand.Seed(time.Now().UnixNano())   // 毎回違う乱数になるようにシードを設定
 		arrayIdx := rand.Intn(len(merged)) // 0 〜 len(A)-1 の範囲で乱数
 		selectedTheme = merged[arrayIdx].Title
+		selectedSource = merged[arrayIdx].Source
+		selectedRank = merged[arrayIdx].Rank
+
+		if err := dedupe.Mark(ctx, selectedTheme); err != nil {
+			log.Printf("WARN: dedupeキャッシュへの記録失敗: %v", err)
+		}
 
 		// Gemini へ渡すプロンプト例（標準出力）
 		fmt.Println("\n=== Theme ===", selectedTheme)
@@ -159,71 +427,36 @@ func main() {
 		fmt.Println("⚠️ テーマが指定されていません。-theme または -t オプションを使用してください。")
 		return
 	}
-	//fmt.Printf("✅ 選択されたプロンプト (%s):\n%s テーマ:%s\n", selectedKey, template, selectedTheme)
-
-	// メッセージ（ここを書き換えれば他の質問もOK）
-	//userInput := "こんにちは！今日のSNSに投稿したくなるようなネタを1つください。"
-	userInput := strings.ReplaceAll(template, "{{THEME}}", selectedTheme)
-	//fmt.Println("💬 ユーザー入力:", userInput)
-
-	// Gemini APIのURL構築
-	endpoint := fmt.Sprintf(
-		"%s/projects/%s/locations/%s/publishers/google/models/%s:generateContent",
-		apiBaseURL, projectID, location, modelID,
-	)
-	//fmt.Println("🔗 リクエストURL:", endpoint)
-
-	// JSONボディ構築
-	requestBody := map[string]interface{}{
-		"contents": []map[string]interface{}{
-			{
-				"role": "user",
-				"parts": []map[string]string{
-					{"text": userInput},
-				},
-			},
-		},
-	}
-	jsonData, _ := json.Marshal(requestBody)
-
-	// アクセストークン取得
-	token, err := getAccessToken()
-	if err != nil {
-		panic(err)
-	}
-	//fmt.Println("🔑 アクセストークン取得成功:", token)
 
-	// リクエスト送信
-	//fmt.Println("📤 リクエスト送信中...", string(jsonData))
-	req, _ := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonData))
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	// Gemini へ問い合わせてネタを生成
+	result, err := generator.Generate(context.Background(), selectedKey, selectedTheme)
 	if err != nil {
-		panic(err)
-	}
-	defer resp.Body.Close()
-	//fmt.Printf("📡 HTTPステータスコード: %d\n", resp.StatusCode)
-
-	// レスポンス読み取り
-	body, _ := io.ReadAll(resp.Body)
-	//fmt.Println("🪵 レスポンスボディ全文:")
-	//fmt.Println(string(body))
-
-	// JSONパース
-	var result GeminiResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		fmt.Println("⚠️ JSONパース失敗:", err)
-		fmt.Println("📦 生データ:", string(body))
+		fmt.Println("⚠️ Gemini生成失敗:", err)
 		return
 	}
+	result.Source = selectedSource
+	result.Rank = selectedRank
 
 	// テキスト部分出力
 	fmt.Println("🔻 Gemini 応答:")
 	for _, candidate := range result.Candidates {
-		for _, part := range candidate.Content.Parts {
-			fmt.Println("👉", part.Text)
+		fmt.Println("👉", candidate)
+	}
+
+	// 配信先シンクが指定されていれば、生成結果を配信する
+	if *sinkFlag != "" {
+		sinkConfigs, err := LoadSinksYaml("./sinks.yaml")
+		if err != nil {
+			fmt.Println("⚠️ シンク設定の読み込み失敗:", err)
+			return
+		}
+		payload := sinks.Payload{
+			PromptKey:  result.PromptKey,
+			Theme:      result.Theme,
+			Source:     result.Source,
+			Rank:       result.Rank,
+			Candidates: result.Candidates,
 		}
+		publishToSinks(context.Background(), strings.Split(*sinkFlag, ","), sinkConfigs, payload)
 	}
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"Netabako/cache"
+	"Netabako/trends"
+)
+
+// serveConfig は、HTTPサーバモードが必要とする依存をまとめたものです。
+type serveConfig struct {
+	generator     *Generator
+	sourceNames   []string
+	sourceConfigs map[string]trends.Config
+	dedupe        cache.Store
+}
+
+// generateRequest は、/generate と /generate/stream が受け取るリクエストボディです。
+type generateRequest struct {
+	PromptKey   string `json:"prompt_key"`
+	Theme       string `json:"theme"`
+	SearchTopic bool   `json:"searchtopic"`
+}
+
+// runServe は、-serve で指定されたアドレスでHTTPサーバを起動し、
+// SIGINT/SIGTERMを受けたらリクエストを受け付けを止めてからグレースフルシャットダウンします。
+func runServe(addr string, cfg serveConfig) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/generate", cfg.handleGenerate)
+	mux.HandleFunc("/trends", cfg.handleTrends)
+	mux.HandleFunc("/generate/stream", cfg.handleGenerateStream)
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	idleClosed := make(chan struct{})
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		<-sigCh
+		log.Println("シャットダウン要求を受信。進行中のリクエストの完了を待っています…")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("WARN: graceful shutdown失敗: %v", err)
+		}
+		close(idleClosed)
+	}()
+
+	log.Printf("🚀 NetaBako サーバ起動: %s", addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	<-idleClosed
+	return nil
+}
+
+// resolveTheme は、searchtopic が指定されていればトレンドを取得してランダムに1件選び、
+// そうでなければリクエストの theme をそのまま使います。
+// CLIの -searchtopic と同様に、dedupeキャッシュにTTL内で記録済みのトピックは除外し、
+// 選んだトピックはその場で記録します。サーバは常駐するため、これをやらないと
+// 同じバズワードに何度も着地してしまいます。
+func (cfg serveConfig) resolveTheme(ctx context.Context, req generateRequest) (string, error) {
+	if !req.SearchTopic {
+		if req.Theme == "" {
+			return "", fmt.Errorf("theme または searchtopic=true のどちらかが必要です")
+		}
+		return req.Theme, nil
+	}
+
+	results := fetchTrends(ctx, cfg.sourceNames, cfg.sourceConfigs, 10)
+	if len(results) == 0 {
+		return "", fmt.Errorf("どのソースからもトピックを取得できませんでした")
+	}
+	merged := trends.MergeAndRank(results, 10)
+	if len(merged) == 0 {
+		return "", fmt.Errorf("マージ後のトピックが0件でした")
+	}
+
+	merged = filterSeen(ctx, cfg.dedupe, merged)
+	if len(merged) == 0 {
+		return "", fmt.Errorf("未使用のトピックがありませんでした（dedupe-ttl内で全て使用済み）")
+	}
+
+	theme := merged[rand.Intn(len(merged))].Title
+	if err := cfg.dedupe.Mark(ctx, theme); err != nil {
+		log.Printf("WARN: dedupeキャッシュへの記録失敗: %v", err)
+	}
+	return theme, nil
+}
+
+func (cfg serveConfig) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POSTメソッドのみ対応しています", http.StatusMethodNotAllowed)
+		return
+	}
+	var req generateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("リクエストボディの解析に失敗しました: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	theme, err := cfg.resolveTheme(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := cfg.generator.Generate(r.Context(), req.PromptKey, theme)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (cfg serveConfig) handleTrends(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "GETメソッドのみ対応しています", http.StatusMethodNotAllowed)
+		return
+	}
+	results := fetchTrends(r.Context(), cfg.sourceNames, cfg.sourceConfigs, 10)
+	merged := trends.MergeAndRank(results, 10)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(merged)
+}
+
+func (cfg serveConfig) handleGenerateStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POSTメソッドのみ対応しています", http.StatusMethodNotAllowed)
+		return
+	}
+	var req generateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("リクエストボディの解析に失敗しました: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	theme, err := cfg.resolveTheme(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "このレスポンスライターはストリーミングに対応していません", http.StatusInternalServerError)
+		return
+	}
+
+	chunks, err := cfg.generator.GenerateStream(r.Context(), req.PromptKey, theme)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for chunk := range chunks {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+		if chunk.Err != "" {
+			// ストリーム途中のエラーはそのままイベントとして送出済みなので、
+			// done:true は送らずにここで終了する。
+			return
+		}
+	}
+	fmt.Fprintf(w, "data: %s\n\n", `{"done":true}`)
+	flusher.Flush()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHandleGenerateStreamPropagatesUpstreamErrorStatus(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":401,"message":"認証に失敗しました"}}`))
+	}))
+	defer upstream.Close()
+
+	cfg := serveConfig{generator: newTestGenerator(upstream.URL)}
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/stream", strings.NewReader(`{"prompt_key":"default","theme":"テーマ"}`))
+	rec := httptest.NewRecorder()
+
+	cfg.handleGenerateStream(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+	if strings.Contains(rec.Body.String(), `"done":true`) {
+		t.Fatalf("上流エラー時に成功扱いのdone:trueが返されました: %s", rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), strconv.Itoa(http.StatusUnauthorized)) {
+		t.Errorf("レスポンスに上流のステータスコードが含まれていません: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,35 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	Register("discord", func(cfg Config) (Sink, error) {
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("sinks: discord シンクには webhook_url の設定が必要です")
+		}
+		return &discordSink{cfg: cfg}, nil
+	})
+}
+
+// discordSink は、Discord Webhookに投稿するシンクです。
+type discordSink struct {
+	cfg Config
+}
+
+func (s *discordSink) Name() string { return "discord" }
+
+func (s *discordSink) Publish(ctx context.Context, payload Payload) error {
+	body := map[string]interface{}{
+		"content": formatText(payload),
+	}
+	if s.cfg.Username != "" {
+		body["username"] = s.cfg.Username
+	}
+	if err := postJSON(ctx, s.cfg.WebhookURL, body, nil); err != nil {
+		return fmt.Errorf("discord webhook: %w", err)
+	}
+	return nil
+}
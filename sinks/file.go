@@ -0,0 +1,43 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func init() {
+	Register("file", func(cfg Config) (Sink, error) {
+		path := cfg.Path
+		if path == "" {
+			path = "./netabako-posts.jsonl"
+		}
+		return &fileSink{path: path}, nil
+	})
+}
+
+// fileSink は、生成結果をJSONL形式でローカルファイルに追記するシンクです。
+// 手元で結果を確認したい場合や、他シンクが未設定の開発環境向けのデフォルトです。
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) Name() string { return "file" }
+
+func (s *fileSink) Publish(ctx context.Context, payload Payload) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("file sink open: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("file sink marshal: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("file sink write: %w", err)
+	}
+	return nil
+}
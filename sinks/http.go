@@ -0,0 +1,58 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// postJSON は、body をJSONエンコードして url にPOSTし、200系以外はエラーにします。
+func postJSON(ctx context.Context, url string, body interface{}, headers map[string]string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %s: %s", resp.Status, string(snippet))
+	}
+	return nil
+}
+
+// formatText は、配信メッセージの本文を組み立てます。全シンク共通のフォーマットです。
+func formatText(p Payload) string {
+	text := fmt.Sprintf("🎯 テーマ: %s", p.Theme)
+	if p.Source != "" {
+		text += fmt.Sprintf("（出典: %s", p.Source)
+		if p.Rank > 0 {
+			text += fmt.Sprintf(" / 順位: %d", p.Rank)
+		}
+		text += "）"
+	}
+	for _, c := range p.Candidates {
+		text += "\n\n" + c
+	}
+	return text
+}
@@ -0,0 +1,43 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+)
+
+const lineMessagingAPIURL = "https://api.line.me/v2/bot/message/push"
+
+func init() {
+	Register("line", func(cfg Config) (Sink, error) {
+		if cfg.ChannelAccessToken == "" {
+			return nil, fmt.Errorf("sinks: line シンクには channel_access_token の設定が必要です")
+		}
+		if cfg.ToUserID == "" {
+			return nil, fmt.Errorf("sinks: line シンクには to_user_id の設定が必要です")
+		}
+		return &lineSink{cfg: cfg}, nil
+	})
+}
+
+// lineSink は、LINE Messaging APIのプッシュメッセージで投稿するシンクです。
+type lineSink struct {
+	cfg Config
+}
+
+func (s *lineSink) Name() string { return "line" }
+
+func (s *lineSink) Publish(ctx context.Context, payload Payload) error {
+	body := map[string]interface{}{
+		"to": s.cfg.ToUserID,
+		"messages": []map[string]string{
+			{"type": "text", "text": formatText(payload)},
+		},
+	}
+	headers := map[string]string{
+		"Authorization": "Bearer " + s.cfg.ChannelAccessToken,
+	}
+	if err := postJSON(ctx, lineMessagingAPIURL, body, headers); err != nil {
+		return fmt.Errorf("line messaging api: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package sinks は、Geminiが生成したネタの配信先（Slack/LINE/Discord/ローカルファイル）を
+// 共通のインターフェースで扱うための出力シンクのサブシステムです。
+//
+// main は、生成結果を Payload に詰めて、-sink フラグで選んだ各 Sink の Publish を
+// 呼ぶだけでよく、配信先ごとのAPI形式の違いを知る必要はありません。
+package sinks
+
+import (
+	"context"
+	"fmt"
+)
+
+// Payload は、各シンクに配信する1回分の生成結果です。
+// フィールドのjsonタグは、/generate のレスポンスで使われる Result と揃えてあります。
+// fileSink はこのタグに従ってJSONLを書き出すため、ファイルシンクとHTTP APIの
+// 出力で同じレコードのキー名が一致します。
+type Payload struct {
+	PromptKey  string   `json:"prompt_key"`       // 使用したプロンプトキー
+	Theme      string   `json:"theme"`            // 選ばれたテーマ（トピックタイトル）
+	Source     string   `json:"source,omitempty"` // テーマの出どころ（"yahoo", "google", "mix"など。手動指定時は空）
+	Rank       int      `json:"rank,omitempty"`   // トレンド内での順位（手動指定時は0）
+	Candidates []string `json:"candidates"`       // Geminiが生成した候補テキスト
+}
+
+// Sink は、生成結果を配信する1つの出力先を表します。
+type Sink interface {
+	// Name はシンクを一意に識別する名前（CLIの -sink で指定する値）を返します。
+	Name() string
+	// Publish は、1回分の生成結果を配信します。
+	Publish(ctx context.Context, payload Payload) error
+}
+
+// Config は、各 Sink を構築する際に渡す設定値です。シンクによって使うフィールドが
+// 異なるため、未使用のフィールドはゼロ値のままで構いません。
+type Config struct {
+	// Slack / Discord のIncoming Webhook URL
+	WebhookURL string
+
+	// Slack向け
+	Channel   string
+	IconEmoji string
+	Username  string
+
+	// LINE Messaging API向け
+	ChannelAccessToken string
+	ToUserID           string
+
+	// fileシンク向け: 追記するJSONLファイルのパス
+	Path string
+}
+
+// Factory は、Config から Sink を1つ組み立てる関数です。
+type Factory func(cfg Config) (Sink, error)
+
+var registry = map[string]Factory{}
+
+// Register は、名前付きの Factory をレジストリに登録します。
+// 各シンク実装の init() から呼び出すことを想定しています。
+func Register(name string, f Factory) {
+	registry[name] = f
+}
+
+// New は、登録済みの Factory を使って名前からシンクを1つ構築します。
+func New(name string, cfg Config) (Sink, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("sinks: 未登録のシンクです: %q", name)
+	}
+	return f(cfg)
+}
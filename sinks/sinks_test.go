@@ -0,0 +1,67 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewUnknownSink(t *testing.T) {
+	if _, err := New("no-such-sink", Config{}); err == nil {
+		t.Fatal("未登録のシンク名でエラーが返りませんでした")
+	}
+}
+
+func TestSlackRequiresWebhookURL(t *testing.T) {
+	if _, err := New("slack", Config{}); err == nil {
+		t.Fatal("webhook_url未指定でエラーが返りませんでした")
+	}
+}
+
+func TestLineRequiresTokenAndUser(t *testing.T) {
+	if _, err := New("line", Config{}); err == nil {
+		t.Fatal("channel_access_token/to_user_id未指定でエラーが返りませんでした")
+	}
+	if _, err := New("line", Config{ChannelAccessToken: "token"}); err == nil {
+		t.Fatal("to_user_id未指定でエラーが返りませんでした")
+	}
+}
+
+func TestFileSinkPublishAppendsJSONL(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "posts.jsonl")
+
+	s, err := New("file", Config{Path: path})
+	if err != nil {
+		t.Fatalf("file sink作成失敗: %v", err)
+	}
+
+	p1 := Payload{PromptKey: "x", Theme: "旅行", Source: "yahoo", Rank: 1, Candidates: []string{"候補A"}}
+	p2 := Payload{PromptKey: "x", Theme: "料理", Source: "google", Rank: 2, Candidates: []string{"候補B"}}
+	if err := s.Publish(context.Background(), p1); err != nil {
+		t.Fatalf("1件目のPublish失敗: %v", err)
+	}
+	if err := s.Publish(context.Background(), p2); err != nil {
+		t.Fatalf("2件目のPublish失敗: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ファイル読み込み失敗: %v", err)
+	}
+	lines := []string{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var got Payload
+		if err := dec.Decode(&got); err != nil {
+			break
+		}
+		lines = append(lines, got.Theme)
+	}
+	if len(lines) != 2 || lines[0] != "旅行" || lines[1] != "料理" {
+		t.Fatalf("JSONLの内容が想定と異なります: %v", lines)
+	}
+}
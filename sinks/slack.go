@@ -0,0 +1,41 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	Register("slack", func(cfg Config) (Sink, error) {
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("sinks: slack シンクには webhook_url の設定が必要です")
+		}
+		return &slackSink{cfg: cfg}, nil
+	})
+}
+
+// slackSink は、Slack Incoming Webhookに投稿するシンクです。
+type slackSink struct {
+	cfg Config
+}
+
+func (s *slackSink) Name() string { return "slack" }
+
+func (s *slackSink) Publish(ctx context.Context, payload Payload) error {
+	body := map[string]interface{}{
+		"text": formatText(payload),
+	}
+	if s.cfg.Channel != "" {
+		body["channel"] = s.cfg.Channel
+	}
+	if s.cfg.IconEmoji != "" {
+		body["icon_emoji"] = s.cfg.IconEmoji
+	}
+	if s.cfg.Username != "" {
+		body["username"] = s.cfg.Username
+	}
+	if err := postJSON(ctx, s.cfg.WebhookURL, body, nil); err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package trends
+
+import "strings"
+
+// normalizeTitle は、比較用にタイトルを正規化します（小文字化＋前後空白除去）。
+func normalizeTitle(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// similarTitles は、2つの正規化済みタイトルが「実質同じ話題」とみなせるかどうかを判定します。
+// 正規化Levenshtein距離（編集距離 / 長い方の文字数）が0.15以下、または
+// スペース区切りトークンのJaccard類似度が0.7以上であれば同一視します。
+// 例: 「藤井聡太 八冠 達成」と「藤井聡太 八冠 達成 速報」はトークンJaccard（3/4）で、
+//
+//	「大谷翔平　ホームラン」のような表記揺れはLevenshteinで吸収されます。
+func similarTitles(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if a == "" || b == "" {
+		return false
+	}
+	if levenshteinRatio(a, b) <= 0.15 {
+		return true
+	}
+	return tokenJaccard(a, b) >= 0.7
+}
+
+// levenshteinRatio は、編集距離を長い方の文字数で割った0〜1の値を返します。
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+	if maxLen == 0 {
+		return 0
+	}
+	return float64(levenshteinDistance(ra, rb)) / float64(maxLen)
+}
+
+func levenshteinDistance(a, b []rune) int {
+	if len(a) == 0 {
+		return len(b)
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+			curr[j] = min3(del, ins, sub)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// tokenJaccard は、空白区切りのトークン集合同士のJaccard類似度（交差/和集合）を返します。
+func tokenJaccard(a, b string) float64 {
+	setA := tokenSet(a)
+	setB := tokenSet(b)
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+	inter := 0
+	for tok := range setA {
+		if setB[tok] {
+			inter++
+		}
+	}
+	union := len(setA) + len(setB) - inter
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
+
+func tokenSet(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, tok := range strings.Fields(s) {
+		set[tok] = true
+	}
+	return set
+}
@@ -0,0 +1,102 @@
+package trends
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	Register("google", func(cfg Config) (TrendSource, error) {
+		weight := cfg.Weight
+		if weight == 0 {
+			weight = 1.0
+		}
+		geo := cfg.Geo
+		if geo == "" {
+			geo = "JP"
+		}
+		return &googleSource{weight: weight, geo: geo}, nil
+	})
+}
+
+type rssFeed struct {
+	Channel rssChannel `xml:"channel"`
+}
+type rssChannel struct {
+	Items []rssItem `xml:"item"`
+}
+type rssItem struct {
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+}
+
+// googleSource は、Googleトレンド（急上昇ワード）のRSSフィードを取得するソースです。
+type googleSource struct {
+	weight float64
+	geo    string
+}
+
+func (s *googleSource) Name() string    { return "google" }
+func (s *googleSource) Weight() float64 { return s.weight }
+
+func (s *googleSource) Fetch(ctx context.Context, max int) ([]Topic, error) {
+	urls := []string{
+		fmt.Sprintf("https://trends.google.com/trends/trendingsearches/daily/rss?hl=ja&geo=%s", s.geo),
+		fmt.Sprintf("https://trends.google.com/trending/rss?geo=%s", s.geo),
+	}
+	var lastErr error
+	for _, url := range urls {
+		resp, err := get(ctx, url)
+		if err != nil {
+			lastErr = fmt.Errorf("google trends request: %w", err)
+			continue
+		}
+		ct := resp.Header.Get("Content-Type")
+		body := io.NopCloser(resp.Body)
+		if !strings.Contains(ct, "xml") {
+			snippet, _ := io.ReadAll(io.LimitReader(body, 512))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("google trends non-XML response: %s ... %q", ct, string(snippet))
+			continue
+		}
+		var rss rssFeed
+		dec := xml.NewDecoder(body)
+		if err := dec.Decode(&rss); err != nil {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("google trends decode: %w", err)
+			continue
+		}
+		resp.Body.Close()
+
+		topics := make([]Topic, 0, len(rss.Channel.Items))
+		reTraffic := regexp.MustCompile(`([0-9,\.]+)\s*万?\+?\s*検索|([0-9,\.]+)\s*searches`)
+		for i, it := range rss.Channel.Items {
+			title := strings.TrimSpace(it.Title)
+			if title == "" {
+				continue
+			}
+			note := ""
+			if m := reTraffic.FindString(it.Description); m != "" {
+				note = m
+			}
+			topics = append(topics, Topic{
+				Source: "google",
+				Title:  title,
+				Note:   note,
+				Rank:   i + 1,
+			})
+			if max > 0 && len(topics) >= max {
+				break
+			}
+		}
+		if len(topics) > 0 {
+			return topics, nil
+		}
+		lastErr = fmt.Errorf("google trends: zero items from %s", url)
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,19 @@
+package trends
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+var httpClient = &http.Client{
+	Timeout: 12 * time.Second,
+}
+
+func get(ctx context.Context, url string) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/125 Safari/537.36")
+	req.Header.Set("Accept", "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5")
+	req.Header.Set("Accept-Language", "ja,en;q=0.8")
+	return httpClient.Do(req)
+}
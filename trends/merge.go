@@ -0,0 +1,139 @@
+package trends
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// agreementBonus は、正規化後に同一話題とみなされたタイトルが2ソース以上に
+// 登場した場合に加算するボーナス値です。
+const agreementBonus = 1.5
+
+// cluster は、fuzzy dedupe でまとめられた「同一話題とみなされたトピック群」の
+// 途中集計です。
+type cluster struct {
+	best    Topic
+	notes   []string
+	sources map[string]bool
+
+	scoreBase   float64
+	trafficPeak float64 // 生のトラフィック推定値の最大値（log10は最後に取る）
+}
+
+// MergeAndRank は、複数ソースの結果をタイトルで束ねてスコアリングし、上位 topN 件を返します。
+//
+// スコアは次の3要素の合計です:
+//  1. ソースごとの基準重み × 1/(rank+1) の総和（ScoreBase）
+//  2. Googleのトラフィック目安（"10万+検索"等）から推定した検索数の log10（ScoreTraffic）
+//  3. 正規化Levenshtein距離やトークンJaccardで同一話題と判定された
+//     タイトルが2ソース以上に登場した場合のボーナス（ScoreAgreement）
+//
+// 同一話題の判定には厳密な正規化キーではなく fuzzy dedupe を使うため、
+// 「大谷翔平 ホームラン」と「大谷翔平　ホームラン」や、「藤井聡太 八冠 達成」と
+// 「藤井聡太 八冠 達成 速報」のような表記揺れも1件に集約されます。
+func MergeAndRank(results []SourceResult, topN int) []Topic {
+	var clusters []*cluster
+
+	findCluster := func(title string) *cluster {
+		norm := normalizeTitle(title)
+		for _, c := range clusters {
+			if similarTitles(norm, normalizeTitle(c.best.Title)) {
+				return c
+			}
+		}
+		return nil
+	}
+
+	for _, result := range results {
+		weight := result.Weight
+		if weight == 0 {
+			weight = 1.0
+		}
+		for _, t := range result.Topics {
+			if strings.TrimSpace(t.Title) == "" {
+				continue
+			}
+			c := findCluster(t.Title)
+			if c == nil {
+				c = &cluster{best: t, sources: map[string]bool{}}
+				clusters = append(clusters, c)
+			}
+
+			rank := t.Rank
+			if rank <= 0 {
+				rank = len(result.Topics) // ランク未設定は最下位相当として弱く加点する
+			}
+			c.scoreBase += weight * (1 / float64(rank+1))
+			c.sources[result.Name] = true
+
+			if t.Note != "" {
+				c.notes = append(c.notes, t.Note)
+				if v, ok := ParseTraffic(t.Note); ok && v > c.trafficPeak {
+					c.trafficPeak = v
+				}
+			}
+			if t.Rank > 0 && (c.best.Rank == 0 || t.Rank < c.best.Rank) {
+				c.best = t
+			}
+		}
+	}
+
+	merged := make([]Topic, 0, len(clusters))
+	for _, c := range clusters {
+		trafficScore := 0.0
+		if c.trafficPeak > 0 {
+			trafficScore = math.Log10(c.trafficPeak)
+		}
+		agreement := 0.0
+		if len(c.sources) >= 2 {
+			agreement = agreementBonus
+		}
+		merged = append(merged, Topic{
+			Source:         "mix",
+			Title:          c.best.Title,
+			Note:           strings.Join(dedupeStrings(c.notes), " / "),
+			Rank:           c.best.Rank,
+			ScoreBase:      c.scoreBase,
+			ScoreTraffic:   trafficScore,
+			ScoreAgreement: agreement,
+			Score:          c.scoreBase + trafficScore + agreement,
+		})
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Score != merged[j].Score {
+			return merged[i].Score > merged[j].Score
+		}
+		// 同点ならRank（小さいほど上位、0=未設定は最下位）、次にタイトル
+		ri, rj := merged[i].Rank, merged[j].Rank
+		if ri == 0 {
+			ri = math.MaxInt32
+		}
+		if rj == 0 {
+			rj = math.MaxInt32
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return merged[i].Title < merged[j].Title
+	})
+
+	if topN > 0 && len(merged) > topN {
+		return merged[:topN]
+	}
+	return merged
+}
+
+func dedupeStrings(in []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
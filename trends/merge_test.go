@@ -0,0 +1,63 @@
+package trends
+
+import "testing"
+
+func TestParseTraffic(t *testing.T) {
+	cases := []struct {
+		note string
+		want float64
+		ok   bool
+	}{
+		{"10万+検索", 100000, true},
+		{"50,000 searches", 50000, true},
+		{"", 0, false},
+		{"よくわからないテキスト", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := ParseTraffic(c.note)
+		if ok != c.ok || got != c.want {
+			t.Errorf("ParseTraffic(%q) = (%v, %v), want (%v, %v)", c.note, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMergeAndRankFuzzyDedupeAndAgreement(t *testing.T) {
+	results := []SourceResult{
+		{Name: "yahoo", Weight: 1.2, Topics: []Topic{
+			{Source: "yahoo", Title: "大谷翔平　ホームラン", Rank: 1}, // 全角スペース違い
+		}},
+		{Name: "google", Weight: 1.0, Topics: []Topic{
+			{Source: "google", Title: "大谷翔平 ホームラン", Note: "10万+検索", Rank: 1},
+			{Source: "google", Title: "無関係の話題", Rank: 2},
+		}},
+	}
+
+	merged := MergeAndRank(results, 10)
+	if len(merged) != 2 {
+		t.Fatalf("got %d topics, want 2 (fuzzy dedupe should collapse the Otani topics)", len(merged))
+	}
+
+	top := merged[0]
+	if top.Title != "大谷翔平　ホームラン" && top.Title != "大谷翔平 ホームラン" {
+		t.Fatalf("unexpected top title: %q", top.Title)
+	}
+	if top.ScoreAgreement == 0 {
+		t.Errorf("expected a cross-source agreement bonus on the merged Otani topic")
+	}
+	if top.ScoreTraffic == 0 {
+		t.Errorf("expected a traffic score from the \"10万+検索\" note")
+	}
+	if top.Score <= merged[1].Score {
+		t.Errorf("merged topic should outrank the single-source topic: %+v vs %+v", top, merged[1])
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	// トークンJaccard: 末尾に1語足しただけの表記揺れ
+	if !similarTitles(normalizeTitle("藤井聡太 八冠 達成"), normalizeTitle("藤井聡太 八冠 達成 速報")) {
+		t.Errorf("expected token-Jaccard to treat these as the same topic")
+	}
+	if similarTitles(normalizeTitle("大谷翔平 ホームラン"), normalizeTitle("藤井聡太 将棋")) {
+		t.Errorf("unrelated topics should not be treated as similar")
+	}
+}
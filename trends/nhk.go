@@ -0,0 +1,127 @@
+package trends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("nhk", func(cfg Config) (TrendSource, error) {
+		weight := cfg.Weight
+		if weight == 0 {
+			weight = 0.8
+		}
+		service := cfg.Service
+		if service == "" {
+			service = "g1"
+		}
+		area := cfg.Area
+		if area == "" {
+			area = "130" // 東京
+		}
+		return &nhkSource{
+			weight:  weight,
+			service: service,
+			area:    area,
+			genre:   cfg.Genre,
+			date:    cfg.Date,
+			keyword: cfg.Keyword,
+		}, nil
+	})
+}
+
+// nhkProgramListResponse は、NHK番組表APIの /v2/pg/list/{area}/{service}/{date}.json
+// レスポンスのうち、このソースが使う部分のみを表します。
+type nhkProgramListResponse struct {
+	List map[string][]struct {
+		Title    string   `json:"title"`
+		SubTitle string   `json:"subtitle"`
+		Genres   []string `json:"genres"`
+	} `json:"list"`
+}
+
+// nhkSource は、NHK番組表APIから指定サービス/エリア/ジャンルの番組を取得し、
+// タイトル・サブタイトルをキーワードでフィルタしてトレンド話題として扱うソースです。
+type nhkSource struct {
+	weight  float64
+	service string
+	area    string
+	genre   string
+	date    string // YYYY-MM-DD（空なら当日）
+	keyword string
+}
+
+func (s *nhkSource) Name() string    { return "nhk" }
+func (s *nhkSource) Weight() float64 { return s.weight }
+
+func (s *nhkSource) Fetch(ctx context.Context, max int) ([]Topic, error) {
+	date := s.date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	url := fmt.Sprintf("https://api.nhk.or.jp/v2/pg/list/%s/%s/%s.json", s.area, s.service, date)
+	resp, err := get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("nhk番組表 request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("nhk番組表 status: %s body: %q", resp.Status, string(b))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("nhk番組表 read: %w", err)
+	}
+	var parsed nhkProgramListResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("nhk番組表 decode: %w", err)
+	}
+
+	programs, ok := parsed.List[s.area]
+	if !ok {
+		return nil, fmt.Errorf("nhk番組表: エリア %q の番組が見つかりません", s.area)
+	}
+
+	topics := make([]Topic, 0, len(programs))
+	for i, p := range programs {
+		title := strings.TrimSpace(p.Title)
+		if title == "" {
+			continue
+		}
+		if s.keyword != "" && !strings.Contains(title, s.keyword) && !strings.Contains(p.SubTitle, s.keyword) {
+			continue
+		}
+		if s.genre != "" && !containsGenre(p.Genres, s.genre) {
+			continue
+		}
+		note := strings.TrimSpace(p.SubTitle)
+		topics = append(topics, Topic{
+			Source: "nhk",
+			Title:  title,
+			Note:   note,
+			Rank:   i + 1,
+		})
+		if max > 0 && len(topics) >= max {
+			break
+		}
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("nhk番組表: 条件に一致する番組がありませんでした")
+	}
+	return topics, nil
+}
+
+func containsGenre(genres []string, want string) bool {
+	for _, g := range genres {
+		if g == want {
+			return true
+		}
+	}
+	return false
+}
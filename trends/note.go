@@ -0,0 +1,99 @@
+package trends
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func init() {
+	// note.com固有ではなく、CSSセレクタで設定する汎用HTMLスクレイパー。
+	// note.comの人気記事一覧のような、記事カード形式のページを想定している。
+	Register("note", func(cfg Config) (TrendSource, error) {
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("trends: note ソースには url の設定が必要です")
+		}
+		itemSel := cfg.ItemSelector
+		if itemSel == "" {
+			return nil, fmt.Errorf("trends: note ソースには item_selector の設定が必要です")
+		}
+		titleSel := cfg.TitleSelector
+		if titleSel == "" {
+			titleSel = "a"
+		}
+		weight := cfg.Weight
+		if weight == 0 {
+			weight = 0.7
+		}
+		return &noteSource{
+			weight:   weight,
+			url:      cfg.URL,
+			itemSel:  itemSel,
+			titleSel: titleSel,
+			linkSel:  cfg.LinkSelector,
+			keyword:  cfg.Keyword,
+		}, nil
+	})
+}
+
+// noteSource は、記事一覧ページをCSSセレクタで指定してスクレイピングする
+// 汎用HTMLソースです。note.comのような「記事カードの繰り返し」構造のページに使えます。
+type noteSource struct {
+	weight   float64
+	url      string
+	itemSel  string
+	titleSel string
+	linkSel  string
+	keyword  string
+}
+
+func (s *noteSource) Name() string    { return "note" }
+func (s *noteSource) Weight() float64 { return s.weight }
+
+func (s *noteSource) Fetch(ctx context.Context, max int) ([]Topic, error) {
+	resp, err := get(ctx, s.url)
+	if err != nil {
+		return nil, fmt.Errorf("note request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("note status: %s body: %q", resp.Status, string(b))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("note parse: %w", err)
+	}
+
+	topics := []Topic{}
+	doc.Find(s.itemSel).EachWithBreak(func(i int, item *goquery.Selection) bool {
+		title := strings.TrimSpace(item.Find(s.titleSel).First().Text())
+		if title == "" {
+			return true
+		}
+		if s.keyword != "" && !strings.Contains(title, s.keyword) {
+			return true
+		}
+		note := ""
+		if s.linkSel != "" {
+			if href, ok := item.Find(s.linkSel).First().Attr("href"); ok {
+				note = strings.TrimSpace(href)
+			}
+		}
+		topics = append(topics, Topic{
+			Source: "note",
+			Title:  title,
+			Note:   note,
+			Rank:   i + 1,
+		})
+		return max <= 0 || len(topics) < max
+	})
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("note: セレクタに一致する記事が見つかりませんでした (%s)", s.url)
+	}
+	return topics, nil
+}
@@ -0,0 +1,70 @@
+package trends
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func init() {
+	// "gnews" という名前だが、汎用のRSS/Atomフィードソース。
+	// sources.yaml の url にGoogleニュース検索RSS
+	// （例: https://news.google.com/rss/search?q=...&hl=ja&gl=JP&ceid=JP:ja）を
+	// 設定して使うことを想定しているが、任意のRSS/Atomフィードを指せる。
+	Register("gnews", func(cfg Config) (TrendSource, error) {
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("trends: gnews ソースには url の設定が必要です")
+		}
+		weight := cfg.Weight
+		if weight == 0 {
+			weight = 0.9
+		}
+		return &rssSource{name: "gnews", url: cfg.URL, keyword: cfg.Keyword, weight: weight}, nil
+	})
+}
+
+// rssSource は、gofeed を使って任意のRSS/Atomフィードからトレンド話題を取得する
+// 汎用ソースです。
+type rssSource struct {
+	name    string
+	url     string
+	keyword string
+	weight  float64
+}
+
+func (s *rssSource) Name() string    { return s.name }
+func (s *rssSource) Weight() float64 { return s.weight }
+
+func (s *rssSource) Fetch(ctx context.Context, max int) ([]Topic, error) {
+	fp := gofeed.NewParser()
+	feed, err := fp.ParseURLWithContext(s.url, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s feed parse: %w", s.name, err)
+	}
+
+	topics := make([]Topic, 0, len(feed.Items))
+	for i, item := range feed.Items {
+		title := strings.TrimSpace(item.Title)
+		if title == "" {
+			continue
+		}
+		if s.keyword != "" && !strings.Contains(title, s.keyword) {
+			continue
+		}
+		topics = append(topics, Topic{
+			Source: s.name,
+			Title:  title,
+			Note:   strings.TrimSpace(item.Published),
+			Rank:   i + 1,
+		})
+		if max > 0 && len(topics) >= max {
+			break
+		}
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("%s: フィードから話題が取得できませんでした (%s)", s.name, s.url)
+	}
+	return topics, nil
+}
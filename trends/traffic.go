@@ -0,0 +1,31 @@
+package trends
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	reTrafficMan      = regexp.MustCompile(`([0-9,\.]+)\s*万\+?\s*検索`)
+	reTrafficSearches = regexp.MustCompile(`([0-9,]+)\s*searches`)
+)
+
+// ParseTraffic は、Googleトレンドのトラフィック目安表記（例: "10万+検索",
+// "50,000 searches"）から検索数の推定値を取り出します。一致しなければ ok=false を返します。
+func ParseTraffic(note string) (value float64, ok bool) {
+	if note == "" {
+		return 0, false
+	}
+	if m := reTrafficMan.FindStringSubmatch(note); m != nil {
+		if n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64); err == nil {
+			return n * 10000, true
+		}
+	}
+	if m := reTrafficSearches.FindStringSubmatch(note); m != nil {
+		if n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,104 @@
+// Package trends は、各種トレンド情報源（Yahooリアルタイム検索、Googleトレンド、
+// RSS/Atomフィード、NHK番組表、note.comなど）を共通のインターフェースで扱うための
+// プラガブルなサブシステムです。
+//
+// main はここで定義された TrendSource を Registry 経由で組み立て、
+// Fetch した結果を MergeAndRank でまとめるだけで、各ソース固有の
+// スクレイピング/API呼び出しの詳細を知る必要はありません。
+package trends
+
+import (
+	"context"
+	"fmt"
+)
+
+// Topic は、いずれかのソースから取得した1件のトレンド話題を表します。
+type Topic struct {
+	Source string // "yahoo", "google", "nhk", "gnews", "note", "mix" など
+	Title  string
+	Note   string // 追加情報（Googleはトラフィック目安、NHKはサブタイトルなど。Yahooは空）
+	Rank   int
+
+	// Score以下は MergeAndRank が算出するランキングスコアです。
+	// 個別ソースの Fetch 結果には立たず、mix（マージ後）の Topic にのみ入ります。
+	Score          float64 // 最終スコア（ScoreBase + ScoreTraffic + ScoreAgreement）
+	ScoreBase      float64 // Σ(ソース重み × 1/(rank+1))
+	ScoreTraffic   float64 // トラフィック推定値（log10）由来のボーナス
+	ScoreAgreement float64 // 複数ソースで同一視された場合のボーナス
+}
+
+// TrendSource は、トレンド話題を取得できる情報源の共通インターフェースです。
+// 新しい情報源を追加する場合は、この3メソッドを実装して Register すれば、
+// main を書き換えることなく -sources フラグから選択できるようになります。
+type TrendSource interface {
+	// Name はソースを一意に識別する名前（CLIの -sources で指定する値）を返します。
+	Name() string
+	// Weight は MergeAndRank でスコアを合成する際に使う重み係数です。
+	// 1.0 を基準とし、sources.yaml の weight で上書きできます。
+	Weight() float64
+	// Fetch はそのソースから最大 max 件のトレンド話題を取得します。
+	Fetch(ctx context.Context, max int) ([]Topic, error)
+}
+
+// Config は、各 TrendSource を構築する際に渡す設定値です。
+// ソースによって使うフィールドが異なるため、未使用のフィールドはゼロ値のままで構いません。
+type Config struct {
+	Weight float64 // 0 の場合はソースごとのデフォルト値が使われる
+
+	// Google / NHK など地域・エリアを指定するソース向け
+	Geo string
+
+	// RSS/Atom系・note.com向け: フィード/ページのURL
+	URL string
+
+	// タイトル/サブタイトルをこのキーワードでフィルタする（空なら無条件）
+	Keyword string
+
+	// NHK番組表API向け
+	Service string // 放送サービス（g1, e1 など）
+	Area    string // 地域コード
+	Genre   string // ジャンルコード
+	Date    string // YYYY-MM-DD（空なら当日）
+
+	// note.com等のHTMLスクレイピング向けCSSセレクタ
+	ItemSelector  string
+	TitleSelector string
+	LinkSelector  string
+}
+
+// SourceResult は、1つの TrendSource から取得した結果を、MergeAndRank が
+// スコア計算に使う重みとセットで束ねたものです。
+type SourceResult struct {
+	Name   string
+	Weight float64
+	Topics []Topic
+}
+
+// Factory は、Config から TrendSource を1つ組み立てる関数です。
+type Factory func(cfg Config) (TrendSource, error)
+
+var registry = map[string]Factory{}
+
+// Register は、名前付きの Factory をレジストリに登録します。
+// 各ソース実装の init() から呼び出すことを想定しています。
+func Register(name string, f Factory) {
+	registry[name] = f
+}
+
+// New は、登録済みの Factory を使って名前からソースを1つ構築します。
+func New(name string, cfg Config) (TrendSource, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("trends: 未登録のソースです: %q", name)
+	}
+	return f(cfg)
+}
+
+// Names は、現在登録されているソース名の一覧を返します。
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
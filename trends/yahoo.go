@@ -0,0 +1,80 @@
+package trends
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func init() {
+	Register("yahoo", func(cfg Config) (TrendSource, error) {
+		weight := cfg.Weight
+		if weight == 0 {
+			weight = 1.2 // Yahooの瞬発力を少し強めに評価する
+		}
+		return &yahooSource{weight: weight}, nil
+	})
+}
+
+// yahooSource は、Yahoo!リアルタイム検索のトレンドページをスクレイピングするソースです。
+type yahooSource struct {
+	weight float64
+}
+
+func (s *yahooSource) Name() string    { return "yahoo" }
+func (s *yahooSource) Weight() float64 { return s.weight }
+
+func (s *yahooSource) Fetch(ctx context.Context, max int) ([]Topic, error) {
+	url := "https://search.yahoo.co.jp/realtime/trend"
+	resp, err := get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo realtime request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("yahoo realtime status: %s body: %q", resp.Status, string(b))
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo realtime parse: %w", err)
+	}
+
+	topics := []Topic{}
+	// 1) trendページのランキング（ol/ul配下のa）を総当りで拾う
+	doc.Find("ol li a, ul li a").Each(func(i int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		txt := strings.TrimSpace(a.Text())
+		if txt == "" || !strings.Contains(href, "/realtime/search") {
+			return
+		}
+		topics = append(topics, Topic{
+			Source: "yahoo",
+			Title:  txt,
+			Rank:   i + 1,
+		})
+	})
+
+	// 重複除去＆切り詰め
+	seen := map[string]bool{}
+	out := make([]Topic, 0, len(topics))
+	for _, t := range topics {
+		key := strings.ToLower(strings.TrimSpace(t.Title))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, t)
+		if max > 0 && len(out) >= max {
+			break
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("yahoo realtime: no topics parsed (DOM changed?)")
+	}
+	return out, nil
+}